@@ -2,18 +2,31 @@ package main
 
 import (
 	"bufio"
+	"bytes"
+	"errors"
 	"fmt"
+	"hash/fnv"
+	"image/color"
 	"io"
 	"os"
 	"os/exec"
+	"os/signal"
+	"reflect"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"syscall"
+	"text/template"
 	"time"
 
 	_ "embed"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
+	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/driver/desktop"
@@ -21,6 +34,7 @@ import (
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 	"github.com/fsnotify/fsnotify"
+	"github.com/zalando/go-keyring"
 	"gopkg.in/yaml.v3"
 )
 
@@ -29,16 +43,641 @@ type Config struct {
 }
 
 type Command struct {
-	Exec string   `yaml:"exec"`
-	Args []string `yaml:"args"`
+	Exec        string            `yaml:"exec"`
+	Args        []string          `yaml:"args"`
+	Notify      *NotifyConfig     `yaml:"notify"`
+	MaxLogLines int               `yaml:"max_log_lines"`
+	Autostart   bool              `yaml:"autostart"`
+	Restart     string            `yaml:"restart"`
+	Schedule    string            `yaml:"schedule"`
+	DependsOn   []string          `yaml:"depends_on"`
+	Env         map[string]string `yaml:"env"`
+	EnvFile     string            `yaml:"env_file"`
+	Cwd         string            `yaml:"cwd"`
+	Shell       bool              `yaml:"shell"`
+	Secrets     []SecretRef       `yaml:"secrets"`
+	StopTimeout int               `yaml:"stop_timeout"`
+	ID          string            `yaml:"id"`
+}
+
+// commandStableID is the key processStates and commandNodes are tracked
+// under across config reloads. It defaults to a hash of tab+name so that
+// editing a command's exec/args/description doesn't re-key its row and
+// orphan a running process; set an explicit id: to keep the identity
+// stable even across a rename.
+func commandStableID(tabName, cmdName string, command Command) string {
+	if command.ID != "" {
+		return command.ID
+	}
+	h := fnv.New64a()
+	h.Write([]byte(tabName))
+	h.Write([]byte{0})
+	h.Write([]byte(cmdName))
+	return fmt.Sprintf("cmd-%x", h.Sum64())
+}
+
+// stopTimeout is how long to wait after SIGTERM before escalating to
+// SIGKILL, defaulting to 10s when the command doesn't set stop_timeout.
+func (c Command) stopTimeout() time.Duration {
+	if c.StopTimeout <= 0 {
+		return 10 * time.Second
+	}
+	return time.Duration(c.StopTimeout) * time.Second
+}
+
+// SecretRef names an environment variable whose value is resolved lazily
+// at launch from exactly one of a keyring entry, a file, or a command's
+// stdout, so secret values never round-trip through the YAML config.
+type SecretRef struct {
+	Name        string `yaml:"name"`
+	FromKeyring string `yaml:"from_keyring"`
+	FromFile    string `yaml:"from_file"`
+	FromCommand string `yaml:"from_command"`
+}
+
+// Restart policies for Command.Restart. The zero value ("") behaves like
+// restartNo.
+const (
+	restartNo        = "no"
+	restartOnFailure = "on-failure"
+	restartAlways    = "always"
+)
+
+// NotifyConfig describes when and how a command should raise a desktop
+// notification as it runs. Title and Body are text/template strings
+// evaluated against a notifyData value.
+type NotifyConfig struct {
+	On            []string `yaml:"on"`
+	StderrPattern string   `yaml:"stderr_pattern"`
+	Title         string   `yaml:"title"`
+	Body          string   `yaml:"body"`
+
+	stderrRegexp *regexp.Regexp
+}
+
+// notifyData is the template context available to NotifyConfig.Title/Body.
+type notifyData struct {
+	Name     string
+	ExitCode int
+	LastLine string
+}
+
+const (
+	notifyOnStart       = "start"
+	notifyOnSuccess     = "success"
+	notifyOnFailure     = "failure"
+	notifyOnStderrMatch = "stderr_match"
+)
+
+func (n *NotifyConfig) has(event string) bool {
+	if n == nil {
+		return false
+	}
+	for _, e := range n.On {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *NotifyConfig) compiledStderrPattern() (*regexp.Regexp, error) {
+	if n.stderrRegexp != nil || n.StderrPattern == "" {
+		return n.stderrRegexp, nil
+	}
+	re, err := regexp.Compile(n.StderrPattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stderr_pattern: %w", err)
+	}
+	n.stderrRegexp = re
+	return n.stderrRegexp, nil
+}
+
+// sendNotify renders the NotifyConfig's title/body templates with data and
+// fires a desktop notification through the Fyne app, falling back to an
+// OS-native tool if fyne's backend can't show one (e.g. headless Linux).
+func sendNotify(n *NotifyConfig, data notifyData) {
+	if n == nil {
+		return
+	}
+	title := renderNotifyTemplate(n.Title, data, data.Name)
+	body := renderNotifyTemplate(n.Body, data, "")
+
+	if fyneNotificationsAvailable() {
+		myApp.SendNotification(fyne.NewNotification(title, body))
+		return
+	}
+
+	if !nativeNotifySupported() {
+		return
+	}
+	if err := sendNativeNotify(title, body); err != nil {
+		fmt.Printf("Error sending native notification: %v\n", err)
+	}
+}
+
+// fyneNotificationsAvailable reports whether myApp can actually show a
+// notification, so sendNotify only falls back to an OS-native tool when
+// it can't — not on every normal desktop session. Fyne's Linux backend
+// needs a running notification daemon reachable over DBus, which isn't
+// the case on a headless X/Wayland-less session.
+func fyneNotificationsAvailable() bool {
+	if myApp == nil {
+		return false
+	}
+	if runtime.GOOS == "linux" && os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == "" {
+		return false
+	}
+	return true
+}
+
+func renderNotifyTemplate(text string, data notifyData, fallback string) string {
+	if text == "" {
+		return fallback
+	}
+	tmpl, err := template.New("notify").Parse(text)
+	if err != nil {
+		fmt.Printf("Error parsing notify template %q: %v\n", text, err)
+		return text
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		fmt.Printf("Error executing notify template %q: %v\n", text, err)
+		return text
+	}
+	return buf.String()
+}
+
+// nativeNotifySupported reports whether this platform has an OS-native
+// notifier we can shell out to as a fallback.
+func nativeNotifySupported() bool {
+	switch runtime.GOOS {
+	case "linux", "darwin", "windows":
+		return true
+	default:
+		return false
+	}
+}
+
+func sendNativeNotify(title, body string) error {
+	switch runtime.GOOS {
+	case "linux":
+		return exec.Command("notify-send", title, body).Run()
+	case "darwin":
+		script := fmt.Sprintf("display notification %q with title %q", body, title)
+		return exec.Command("osascript", "-e", script).Run()
+	case "windows":
+		ps := fmt.Sprintf(
+			"[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] | Out-Null; "+
+				"$msg = New-Object -ComObject WScript.Shell; $msg.Popup(%q, 0, %q)",
+			body, title)
+		return exec.Command("powershell", "-Command", ps).Run()
+	default:
+		return nil
+	}
+}
+
+// buildExecCommand turns a Command's exec/args/shell/cwd/env/secrets into
+// a ready-to-start exec.Cmd, plus the names (never values) of every
+// environment variable it injects so the row UI can display them masked.
+func buildExecCommand(command Command) (*exec.Cmd, []string, error) {
+	var cmd *exec.Cmd
+	if command.Shell {
+		line := command.Exec
+		if len(command.Args) > 0 {
+			line = line + " " + strings.Join(command.Args, " ")
+		}
+		if runtime.GOOS == "windows" {
+			cmd = exec.Command("cmd", "/C", line)
+		} else {
+			cmd = exec.Command("/bin/sh", "-c", line)
+		}
+	} else {
+		cmd = exec.Command(command.Exec, command.Args...)
+	}
+
+	if command.Cwd != "" {
+		cmd.Dir = command.Cwd
+	}
+
+	env := os.Environ()
+	var names []string
+
+	for key, value := range command.Env {
+		env = append(env, key+"="+value)
+		names = append(names, key)
+	}
+
+	if command.EnvFile != "" {
+		vars, err := parseDotenvFile(command.EnvFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load env_file: %w", err)
+		}
+		for key, value := range vars {
+			env = append(env, key+"="+value)
+			names = append(names, key)
+		}
+	}
+
+	for _, secret := range command.Secrets {
+		value, err := resolveSecret(secret)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to resolve secret %q: %w", secret.Name, err)
+		}
+		env = append(env, secret.Name+"="+value)
+		names = append(names, secret.Name)
+	}
+
+	cmd.Env = env
+	sort.Strings(names)
+	configureProcessGroup(cmd)
+
+	return cmd, names, nil
+}
+
+// parseDotenvFile reads a dotenv-style file (KEY=VALUE per line, blank
+// lines and "#" comments ignored, values may be wrapped in quotes).
+func parseDotenvFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	vars := make(map[string]string)
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' && value[len(value)-1] == '"' || value[0] == '\'' && value[len(value)-1] == '\'') {
+			value = value[1 : len(value)-1]
+		}
+		vars[key] = value
+	}
+
+	return vars, nil
+}
+
+// resolveSecret fetches a secret's value from exactly one of its sources,
+// called lazily at process launch time so values never sit in Config or
+// get written to state.Logs.
+func resolveSecret(secret SecretRef) (string, error) {
+	switch {
+	case secret.FromFile != "":
+		data, err := os.ReadFile(secret.FromFile)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(data)), nil
+
+	case secret.FromCommand != "":
+		var cmd *exec.Cmd
+		if runtime.GOOS == "windows" {
+			cmd = exec.Command("cmd", "/C", secret.FromCommand)
+		} else {
+			cmd = exec.Command("/bin/sh", "-c", secret.FromCommand)
+		}
+		out, err := cmd.Output()
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(out)), nil
+
+	case secret.FromKeyring != "":
+		service, account, found := strings.Cut(secret.FromKeyring, "/")
+		if !found {
+			return "", fmt.Errorf("from_keyring %q must be in \"service/account\" form", secret.FromKeyring)
+		}
+		return keyring.Get(service, account)
+
+	default:
+		return "", fmt.Errorf("secret %q must set from_keyring, from_file or from_command", secret.Name)
+	}
+}
+
+// injectedEnvNames lists the names (never values) of every env var a
+// Command will inject, for the row UI's masked preview.
+func injectedEnvNames(command Command) []string {
+	var names []string
+	for key := range command.Env {
+		names = append(names, key)
+	}
+	if command.EnvFile != "" {
+		if vars, err := parseDotenvFile(command.EnvFile); err == nil {
+			for key := range vars {
+				names = append(names, key)
+			}
+		}
+	}
+	for _, secret := range command.Secrets {
+		names = append(names, secret.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CronSchedule is a parsed five-field cron expression (minute hour
+// day-of-month month day-of-week), supporting "*", "*/step", "a-b" ranges
+// and "a,b,c" lists in each field.
+type CronSchedule struct {
+	minute map[int]bool
+	hour   map[int]bool
+	dom    map[int]bool
+	month  map[int]bool
+	dow    map[int]bool
+
+	domWild bool
+	dowWild bool
+}
+
+func parseCronSchedule(expr string) (*CronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields, got %d", expr, len(fields))
+	}
+
+	minute, err := parseCronField(fields[0], 0, 59)
+	if err != nil {
+		return nil, fmt.Errorf("minute field: %w", err)
+	}
+	hour, err := parseCronField(fields[1], 0, 23)
+	if err != nil {
+		return nil, fmt.Errorf("hour field: %w", err)
+	}
+	dom, err := parseCronField(fields[2], 1, 31)
+	if err != nil {
+		return nil, fmt.Errorf("day-of-month field: %w", err)
+	}
+	month, err := parseCronField(fields[3], 1, 12)
+	if err != nil {
+		return nil, fmt.Errorf("month field: %w", err)
+	}
+	dow, err := parseCronField(fields[4], 0, 6)
+	if err != nil {
+		return nil, fmt.Errorf("day-of-week field: %w", err)
+	}
+
+	return &CronSchedule{
+		minute:  minute,
+		hour:    hour,
+		dom:     dom,
+		month:   month,
+		dow:     dow,
+		domWild: fields[2] == "*",
+		dowWild: fields[4] == "*",
+	}, nil
+}
+
+// parseCronField expands a single cron field ("*", "*/n", "a-b", "a-b/n",
+// or a comma-separated list of those) into the set of matching values.
+func parseCronField(field string, min, max int) (map[int]bool, error) {
+	values := make(map[int]bool)
+
+	for _, part := range strings.Split(field, ",") {
+		rangeExpr, step := part, 1
+		if idx := strings.Index(part, "/"); idx >= 0 {
+			var err error
+			step, err = strconv.Atoi(part[idx+1:])
+			if err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step in %q", part)
+			}
+			rangeExpr = part[:idx]
+		}
+
+		lo, hi := min, max
+		if rangeExpr != "*" {
+			if dash := strings.Index(rangeExpr, "-"); dash >= 0 {
+				var err error
+				lo, err = strconv.Atoi(rangeExpr[:dash])
+				if err != nil {
+					return nil, fmt.Errorf("invalid range start in %q", rangeExpr)
+				}
+				hi, err = strconv.Atoi(rangeExpr[dash+1:])
+				if err != nil {
+					return nil, fmt.Errorf("invalid range end in %q", rangeExpr)
+				}
+			} else {
+				single, err := strconv.Atoi(rangeExpr)
+				if err != nil {
+					return nil, fmt.Errorf("invalid value %q", rangeExpr)
+				}
+				lo, hi = single, single
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			if v < min || v > max {
+				return nil, fmt.Errorf("value %d out of range [%d, %d]", v, min, max)
+			}
+			values[v] = true
+		}
+	}
+
+	return values, nil
+}
+
+// Matches reports whether t falls on this schedule, to minute precision.
+// When both day-of-month and day-of-week are restricted (not "*"), cron
+// semantics match if either one matches, not both.
+func (c *CronSchedule) Matches(t time.Time) bool {
+	if !c.minute[t.Minute()] || !c.hour[t.Hour()] || !c.month[int(t.Month())] {
+		return false
+	}
+	domMatch := c.dom[t.Day()]
+	dowMatch := c.dow[int(t.Weekday())]
+	if c.domWild || c.dowWild {
+		return (c.domWild || domMatch) && (c.dowWild || dowMatch)
+	}
+	return domMatch || dowMatch
+}
+
+// Next returns the first minute-aligned time strictly after `after` that
+// matches the schedule, giving up after scanning two years of minutes.
+func (c *CronSchedule) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	for i := 0; i < 2*366*24*60; i++ {
+		if c.Matches(t) {
+			return t
+		}
+		t = t.Add(time.Minute)
+	}
+	return time.Time{}
 }
 
 type ProcessState struct {
-	Cmd     *exec.Cmd
-	Running bool
-	Output  []string
-	Mutex   sync.Mutex
-	HasRun  bool
+	Cmd           *exec.Cmd
+	Running       bool
+	Stopping      bool
+	ExitCode      int
+	Logs          []LogEntry
+	MaxLogLines   int
+	Mutex         sync.Mutex
+	HasRun        bool
+	Subscribers   []chan LogEntry
+	LastExitOK    bool
+	StoppedByUser bool
+}
+
+// stopProcessGroup sends SIGTERM to cmd's process group, waits up to
+// timeout for it to exit, and escalates to SIGKILL if it's still alive.
+// It blocks until the group is gone (or escalation has been sent), so
+// callers that must not block the UI should run it in a goroutine.
+func stopProcessGroup(state *ProcessState, cmd *exec.Cmd, timeout time.Duration) {
+	if cmd == nil {
+		return
+	}
+
+	if err := terminateProcessGroup(cmd); err != nil {
+		fmt.Printf("Error sending SIGTERM to process group: %v\n", err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		state.Mutex.Lock()
+		running := state.Running
+		state.Mutex.Unlock()
+		if !running {
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	state.Mutex.Lock()
+	running := state.Running
+	state.Mutex.Unlock()
+	if running {
+		state.appendLog(streamSystem, fmt.Sprintf("Process did not stop within %s, sending SIGKILL", timeout))
+		if err := killProcessGroup(cmd); err != nil {
+			fmt.Printf("Error sending SIGKILL to process group: %v\n", err)
+		}
+	}
+}
+
+// stopAllProcesses gracefully stops every running command's process
+// group in parallel, waiting for all of them before returning. Used by
+// main's signal handler so cmdeck doesn't leave children running or
+// orphaned when the user closes it with Ctrl-C or a SIGTERM.
+func stopAllProcesses() {
+	nodesMutex.Lock()
+	nodes := make([]*commandNode, 0, len(commandNodes))
+	for _, node := range commandNodes {
+		nodes = append(nodes, node)
+	}
+	nodesMutex.Unlock()
+
+	var wg sync.WaitGroup
+	for _, node := range nodes {
+		node.State.Mutex.Lock()
+		running := node.State.Running
+		cmd := node.State.Cmd
+		node.State.StoppedByUser = true
+		node.State.Mutex.Unlock()
+		if !running {
+			continue
+		}
+
+		wg.Add(1)
+		go func(node *commandNode, cmd *exec.Cmd) {
+			defer wg.Done()
+			stopProcessGroup(node.State, cmd, node.Row.Command.stopTimeout())
+		}(node, cmd)
+	}
+	wg.Wait()
+}
+
+// defaultMaxLogLines is used when a Command doesn't set max_log_lines.
+const defaultMaxLogLines = 2000
+
+const (
+	streamStdout = "stdout"
+	streamStderr = "stderr"
+	streamSystem = "system"
+)
+
+// LogEntry is a single line captured from a running process, kept in a
+// ring buffer on ProcessState so long-running commands don't grow Logs
+// without bound.
+type LogEntry struct {
+	Time   time.Time
+	Stream string
+	Text   string
+}
+
+// resetLogs clears the ring buffer for a fresh run and applies the
+// command's configured (or default) capacity.
+func (s *ProcessState) resetLogs(maxLogLines int) {
+	if maxLogLines <= 0 {
+		maxLogLines = defaultMaxLogLines
+	}
+	s.Mutex.Lock()
+	s.MaxLogLines = maxLogLines
+	s.Logs = nil
+	s.Mutex.Unlock()
+}
+
+// appendLog records a log line, trims the ring buffer to MaxLogLines, and
+// broadcasts the entry to any subscribed log viewers.
+func (s *ProcessState) appendLog(stream, text string) LogEntry {
+	entry := LogEntry{Time: time.Now(), Stream: stream, Text: text}
+
+	s.Mutex.Lock()
+	s.Logs = append(s.Logs, entry)
+	if s.MaxLogLines > 0 && len(s.Logs) > s.MaxLogLines {
+		s.Logs = s.Logs[len(s.Logs)-s.MaxLogLines:]
+	}
+	subscribers := make([]chan LogEntry, len(s.Subscribers))
+	copy(subscribers, s.Subscribers)
+	s.Mutex.Unlock()
+
+	for _, ch := range subscribers {
+		select {
+		case ch <- entry:
+		default:
+		}
+	}
+
+	return entry
+}
+
+// subscribe registers a channel that receives every LogEntry appended from
+// now on, used by the "follow tail" mode so the viewer doesn't have to
+// poll. The returned function must be called to unsubscribe.
+func (s *ProcessState) subscribe() (<-chan LogEntry, func()) {
+	ch := make(chan LogEntry, 64)
+
+	s.Mutex.Lock()
+	s.Subscribers = append(s.Subscribers, ch)
+	s.Mutex.Unlock()
+
+	unsubscribe := func() {
+		s.Mutex.Lock()
+		for i, c := range s.Subscribers {
+			if c == ch {
+				s.Subscribers = append(s.Subscribers[:i], s.Subscribers[i+1:]...)
+				break
+			}
+		}
+		s.Mutex.Unlock()
+		close(ch)
+	}
+
+	return ch, unsubscribe
+}
+
+// snapshotLogs returns a copy of the current log buffer safe to read
+// without holding the mutex.
+func (s *ProcessState) snapshotLogs() []LogEntry {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+	logs := make([]LogEntry, len(s.Logs))
+	copy(logs, s.Logs)
+	return logs
 }
 
 type TabData struct {
@@ -47,21 +686,291 @@ type TabData struct {
 }
 
 type RowData struct {
+	ID          string
+	Tab         string
 	Title       string
 	Description string
 	Command     Command
+	DependsOn   []string
 }
 
 var (
-	processStates = make(map[string]*ProcessState)
-	config        Config
-	configFile    string
-	myApp         fyne.App
-	myWindow      fyne.Window
-	tabsContainer *container.AppTabs
-	configWatcher *fsnotify.Watcher
+	processStatesMutex sync.Mutex
+	processStates      = make(map[string]*ProcessState)
+	config             Config
+	configFile         string
+	myApp              fyne.App
+	myWindow           fyne.Window
+	tabsContainer      *container.AppTabs
+	configWatcher      *fsnotify.Watcher
+
+	centerHolder     *fyne.Container
+	errorBanner      *fyne.Container
+	errorBannerLabel *widget.Label
 )
 
+// getOrCreateProcessState returns the ProcessState for key, creating one
+// if this is the first time the row has been seen, under processStatesMutex
+// so hot-reload's map delete (stopRemovedRows) can't race a concurrent
+// lookup from the supervisor ticker, a restart-backoff callback, or a
+// captureOutput goroutine.
+func getOrCreateProcessState(key string) *ProcessState {
+	processStatesMutex.Lock()
+	defer processStatesMutex.Unlock()
+	state, exists := processStates[key]
+	if !exists {
+		state = &ProcessState{MaxLogLines: defaultMaxLogLines}
+		processStates[key] = state
+	}
+	return state
+}
+
+// getProcessState looks up the ProcessState for key without creating one.
+func getProcessState(key string) (*ProcessState, bool) {
+	processStatesMutex.Lock()
+	defer processStatesMutex.Unlock()
+	state, exists := processStates[key]
+	return state, exists
+}
+
+// deleteProcessState removes key's ProcessState, e.g. once its row has
+// been confirmed stopped and removed from the live config.
+func deleteProcessState(key string) {
+	processStatesMutex.Lock()
+	defer processStatesMutex.Unlock()
+	delete(processStates, key)
+}
+
+// processState returns key's ProcessState, or nil if it's since been
+// removed (e.g. by deleteProcessState on a hot-reload). Callers that
+// create the row's state up front with getOrCreateProcessState and run
+// on the Fyne main thread (not a background goroutine racing a reload)
+// can treat the result as always non-nil.
+func processState(key string) *ProcessState {
+	processStatesMutex.Lock()
+	defer processStatesMutex.Unlock()
+	return processStates[key]
+}
+
+// buildErrorBanner builds the dismissible, non-modal banner shown when a
+// config reload fails validation, in place of a dialog.ShowError that
+// would otherwise steal focus on every save.
+func buildErrorBanner() *fyne.Container {
+	errorBannerLabel = widget.NewLabel("")
+	errorBannerLabel.Wrapping = fyne.TextWrapWord
+
+	dismiss := widget.NewButtonWithIcon("", theme.CancelIcon(), func() {
+		errorBanner.Hide()
+	})
+
+	errorBanner = container.NewBorder(nil, nil, widget.NewIcon(theme.WarningIcon()), dismiss, errorBannerLabel)
+	errorBanner.Hide()
+	return errorBanner
+}
+
+func showConfigErrorBanner(message string) {
+	errorBannerLabel.SetText(message)
+	errorBanner.Show()
+}
+
+func hideConfigErrorBanner() {
+	errorBanner.Hide()
+}
+
+// commandNode is the supervisor's view of a command: its dependencies,
+// its cron schedule (if any), and the Start closure that reuses the same
+// launch logic as the row's play button. It's tracked in commandNodes
+// under Row.ID (see registerCommandNode); Name is only for log messages.
+type commandNode struct {
+	Name            string
+	Row             RowData
+	State           *ProcessState
+	DependsOn       []string
+	Start           func()
+	cron            *CronSchedule
+	nextCronRun     time.Time
+	autostarted     bool
+	restartAttempts int
+}
+
+var (
+	nodesMutex   sync.Mutex
+	commandNodes = make(map[string]*commandNode)
+)
+
+// registerCommandNode installs node as the supervisor's entry for its
+// row's stable ID (see commandStableID) — not its bare name, since
+// nothing stops two different tabs from using the same command name, and
+// keying by name would let one silently shadow the other. Hot-reload
+// rebuilds a fresh commandNode for every row on every save, so if a node
+// already exists for this ID and its command is unchanged, the previous
+// node's runtime state (autostarted, restart attempts, cron schedule) is
+// carried over rather than reset — otherwise an unrelated edit elsewhere
+// in the config would re-autostart or re-arm the cron schedule of every
+// other command on the next tick.
+func registerCommandNode(node *commandNode) {
+	nodesMutex.Lock()
+	defer nodesMutex.Unlock()
+	if previous, exists := commandNodes[node.Row.ID]; exists && reflect.DeepEqual(previous.Row.Command, node.Row.Command) {
+		node.autostarted = previous.autostarted
+		node.restartAttempts = previous.restartAttempts
+		node.cron = previous.cron
+		node.nextCronRun = previous.nextCronRun
+	}
+	commandNodes[node.Row.ID] = node
+}
+
+// dependenciesSatisfied reports whether every command node points to is
+// either currently running or has run and its most recent run exited
+// successfully. Dependencies with no registered node are skipped.
+func dependenciesSatisfied(node *commandNode) bool {
+	nodesMutex.Lock()
+	defer nodesMutex.Unlock()
+	for _, dep := range node.DependsOn {
+		depNode, exists := commandNodes[dep]
+		if !exists {
+			continue
+		}
+		depNode.State.Mutex.Lock()
+		ok := depNode.State.Running || (depNode.State.HasRun && depNode.State.LastExitOK)
+		depNode.State.Mutex.Unlock()
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// depGraphEntry is validateDependencyGraph's view of one command: its
+// tab-qualified label (for error messages) and its depends_on resolved
+// to the stable IDs of sibling commands in the same tab.
+type depGraphEntry struct {
+	label string
+	deps  []string
+}
+
+// validateDependencyGraph checks that every depends_on reference resolves
+// to a known command in the same tab and that the resulting graph has no
+// cycles, so loadConfig can refuse to load a broken supervisor
+// configuration. Commands are keyed by their stable ID (not bare name)
+// since nothing stops two different tabs from using the same command
+// name.
+func validateDependencyGraph(config Config) error {
+	deps := make(map[string]depGraphEntry)
+	for tabName, commands := range config.Tabs {
+		for name, command := range commands {
+			id := commandStableID(tabName, name, command)
+			label := fmt.Sprintf("%s/%s", tabName, name)
+
+			resolved := make([]string, 0, len(command.DependsOn))
+			for _, dep := range command.DependsOn {
+				depCommand, exists := commands[dep]
+				if !exists {
+					return fmt.Errorf("command %q depends_on unknown command %q in tab %q", name, dep, tabName)
+				}
+				resolved = append(resolved, commandStableID(tabName, dep, depCommand))
+			}
+			deps[id] = depGraphEntry{label: label, deps: resolved}
+		}
+	}
+
+	const (
+		unvisited = 0
+		visiting  = 1
+		visited   = 2
+	)
+	state := make(map[string]int, len(deps))
+
+	var visit func(id string, path []string) error
+	visit = func(id string, path []string) error {
+		switch state[id] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("cycle detected in depends_on: %s -> %s", strings.Join(path, " -> "), deps[id].label)
+		}
+		state[id] = visiting
+		for _, dep := range deps[id].deps {
+			if err := visit(dep, append(path, deps[id].label)); err != nil {
+				return err
+			}
+		}
+		state[id] = visited
+		return nil
+	}
+
+	for id := range deps {
+		if err := visit(id, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// runSupervisor drives autostart, cron schedules and dependency-gated
+// launches for every registered command, akin to a tiny per-user init
+// system running alongside the tray UI.
+func runSupervisor() {
+	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		nodesMutex.Lock()
+		nodes := make([]*commandNode, 0, len(commandNodes))
+		for _, node := range commandNodes {
+			nodes = append(nodes, node)
+		}
+		nodesMutex.Unlock()
+
+		now := time.Now()
+		for _, node := range nodes {
+			node.State.Mutex.Lock()
+			running := node.State.Running
+			node.State.Mutex.Unlock()
+			if running || !dependenciesSatisfied(node) {
+				continue
+			}
+
+			if node.Row.Command.Autostart && !node.autostarted {
+				node.autostarted = true
+				node.Start()
+				continue
+			}
+
+			if node.Row.Command.Schedule == "" {
+				continue
+			}
+			if node.cron == nil {
+				cron, err := parseCronSchedule(node.Row.Command.Schedule)
+				if err != nil {
+					fmt.Printf("Error parsing schedule for %q: %v\n", node.Name, err)
+					continue
+				}
+				node.cron = cron
+				node.nextCronRun = cron.Next(now)
+			}
+			if !node.nextCronRun.IsZero() && !now.Before(node.nextCronRun) {
+				node.Start()
+				node.nextCronRun = node.cron.Next(now)
+			}
+		}
+	}
+}
+
+// restartBackoff grows geometrically with each automatic restart attempt,
+// capped so a crash-looping command doesn't spin the CPU.
+func restartBackoff(attempt int) time.Duration {
+	backoff := time.Second
+	for i := 1; i < attempt; i++ {
+		backoff *= 2
+		if backoff >= 30*time.Second {
+			return 30 * time.Second
+		}
+	}
+	return backoff
+}
+
 //go:embed icon.png
 var iconData []byte
 
@@ -88,6 +997,7 @@ func main() {
 			fyne.NewMenuItemSeparator(),
 			fyne.NewMenuItem("Exit", func() {
 				stopConfigWatcher()
+				stopAllProcesses()
 				myApp.Quit()
 			}),
 		)
@@ -99,47 +1009,186 @@ func main() {
 		myWindow.Hide()
 	})
 
+	centerHolder = container.NewStack()
+	myWindow.SetContent(container.NewBorder(buildErrorBanner(), nil, nil, nil, centerHolder))
+
 	loadConfigAndRefreshUI()
 
 	go watchConfigFile()
+	go runSupervisor()
+	go handleShutdownSignals()
 
 	myWindow.ShowAndRun()
 }
 
+// handleShutdownSignals stops every running command's process group
+// before quitting on SIGINT/SIGTERM, so closing cmdeck from a terminal
+// doesn't orphan whatever it was supervising.
+func handleShutdownSignals() {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	<-sigCh
+
+	fmt.Println("Received shutdown signal, stopping running processes...")
+	stopConfigWatcher()
+	stopAllProcesses()
+	myApp.Quit()
+}
+
+// loadConfigAndRefreshUI parses configFile into a staging Config, and
+// only swaps it in for the live `config` (rebuilding the UI) once it's
+// validated and any row whose process is still running has been
+// confirmed removable by the user. A failed parse/validate shows a
+// dismissible banner instead of rebuilding anything.
 func loadConfigAndRefreshUI() {
-	err := loadConfig(configFile)
+	newConfig, err := parseConfig(configFile)
 	if err != nil {
 		fmt.Printf("Error loading config: %v\n", err)
-		dialog.ShowError(err, myWindow)
+		showConfigErrorBanner(fmt.Sprintf("Error loading config: %v", err))
+		return
+	}
+
+	removed := runningRowsBeingRemoved(config, newConfig)
+	if len(removed) == 0 {
+		applyConfig(newConfig)
 		return
 	}
 
+	labels := make([]string, len(removed))
+	for i, r := range removed {
+		labels[i] = r.Label
+	}
+	message := fmt.Sprintf("The following running commands were removed or renamed in %s:\n\n%s\n\nStop them and apply the change?",
+		configFile, strings.Join(labels, "\n"))
+	dialog.ShowConfirm("Running processes will be removed", message, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		stopRemovedRows(removed)
+		applyConfig(newConfig)
+	}, myWindow)
+}
+
+// applyConfig commits a validated Config as the live one and rebuilds the
+// tab/row widgets. Rows keep the same processStates entry across the
+// rebuild because it's keyed by RowData.ID, which is stable across
+// cosmetic edits (see commandStableID). commandNodes are keyed by the
+// same ID and rebuilt on every call, but registerCommandNode carries over
+// runtime state for nodes whose command didn't change.
+func applyConfig(newConfig Config) {
+	config = newConfig
+
 	tabsData := convertConfigToTabsData(config)
 
 	tabsContainer = container.NewAppTabs()
-
 	for _, tabData := range tabsData {
 		tabContent := createTabContent(tabData)
 		tabsContainer.Append(container.NewTabItem(tabData.Title, tabContent))
 	}
 
-	myWindow.SetContent(tabsContainer)
+	centerHolder.Objects = []fyne.CanvasObject{tabsContainer}
+	centerHolder.Refresh()
+	hideConfigErrorBanner()
 }
 
-func loadConfig(filename string) error {
+// removedRow describes a command present in oldConfig but not in
+// newConfig (by stable ID) whose process is still running.
+type removedRow struct {
+	ID    string
+	Name  string
+	Label string
+}
+
+// runningRowsBeingRemoved finds commands that would disappear (removed or
+// renamed so their stable ID no longer resolves) when switching from
+// oldConfig to newConfig and are currently running.
+func runningRowsBeingRemoved(oldConfig, newConfig Config) []removedRow {
+	newIDs := make(map[string]bool)
+	for tabName, commands := range newConfig.Tabs {
+		for cmdName, command := range commands {
+			newIDs[commandStableID(tabName, cmdName, command)] = true
+		}
+	}
+
+	var removed []removedRow
+	for tabName, commands := range oldConfig.Tabs {
+		for cmdName, command := range commands {
+			id := commandStableID(tabName, cmdName, command)
+			if newIDs[id] {
+				continue
+			}
+			state, exists := getProcessState(id)
+			if !exists {
+				continue
+			}
+			state.Mutex.Lock()
+			running := state.Running
+			state.Mutex.Unlock()
+			if running {
+				removed = append(removed, removedRow{
+					ID:    id,
+					Name:  cmdName,
+					Label: fmt.Sprintf("%s / %s", tabName, cmdName),
+				})
+			}
+		}
+	}
+	return removed
+}
+
+// stopRemovedRows gracefully stops and forgets commands the user
+// confirmed removing, so they don't keep running headless with no row
+// left to control them.
+func stopRemovedRows(removed []removedRow) {
+	for _, r := range removed {
+		state, exists := getProcessState(r.ID)
+		if !exists {
+			continue
+		}
+		state.Mutex.Lock()
+		state.StoppedByUser = true
+		cmd := state.Cmd
+		state.Mutex.Unlock()
+
+		go stopProcessGroup(state, cmd, defaultStopTimeout)
+
+		deleteProcessState(r.ID)
+		nodesMutex.Lock()
+		delete(commandNodes, r.ID)
+		nodesMutex.Unlock()
+	}
+}
+
+// defaultStopTimeout is used when stopping a row that no longer has a
+// Command to read stop_timeout from (it was just removed from config).
+const defaultStopTimeout = 10 * time.Second
+
+// parseConfig reads and validates configFile without touching the live
+// `config` package variable, so callers can diff the result against the
+// current config before deciding whether to swap it in.
+func parseConfig(filename string) (Config, error) {
 	data, err := os.ReadFile(filename)
 	if err != nil {
-		return fmt.Errorf("failed to read config file: %w", err)
+		return Config{}, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	err = yaml.Unmarshal(data, &config)
-	if err != nil {
-		return fmt.Errorf("failed to parse YAML: %w", err)
+	var staged Config
+	if err := yaml.Unmarshal(data, &staged); err != nil {
+		return Config{}, fmt.Errorf("failed to parse YAML: %w", err)
 	}
 
-	return nil
+	if err := validateDependencyGraph(staged); err != nil {
+		return Config{}, fmt.Errorf("invalid depends_on configuration: %w", err)
+	}
+
+	return staged, nil
 }
 
+// watchConfigFile watches configFile for changes and reloads, debouncing
+// bursts of fsnotify events (many editors write -> rename -> chmod on
+// every save) and re-adding the watch on every event, since an atomic
+// save that renames a new inode over configFile silently drops the old
+// watch.
 func watchConfigFile() {
 	var err error
 	configWatcher, err = fsnotify.NewWatcher()
@@ -149,25 +1198,38 @@ func watchConfigFile() {
 	}
 	defer configWatcher.Close()
 
-	err = configWatcher.Add(configFile)
-	if err != nil {
+	if err := configWatcher.Add(configFile); err != nil {
 		fmt.Printf("Error watching config file: %v\n", err)
 		return
 	}
 
 	fmt.Printf("Watching config file: %s\n", configFile)
 
+	const debounce = 250 * time.Millisecond
+	var debounceTimer *time.Timer
+
 	for {
 		select {
 		case event, ok := <-configWatcher.Events:
 			if !ok {
 				return
 			}
-			if event.Op&fsnotify.Write == fsnotify.Write || event.Op&fsnotify.Create == fsnotify.Create {
-				fmt.Println("Config file modified, reloading...")
-				time.Sleep(100 * time.Millisecond)
-				loadConfigAndRefreshUI()
+
+			if err := configWatcher.Add(configFile); err != nil {
+				fmt.Printf("Error re-adding config file watch: %v\n", err)
+			}
+
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+				continue
+			}
+
+			if debounceTimer != nil {
+				debounceTimer.Stop()
 			}
+			debounceTimer = time.AfterFunc(debounce, func() {
+				fmt.Println("Config file modified, reloading...")
+				fyne.Do(loadConfigAndRefreshUI)
+			})
 		case err, ok := <-configWatcher.Errors:
 			if !ok {
 				return
@@ -195,10 +1257,24 @@ func convertConfigToTabsData(config Config) []TabData {
 				description = fullDesc[:57] + "..."
 			}
 
+			// depends_on names a sibling command in the same tab;
+			// validateDependencyGraph already rejected the config if any
+			// name doesn't resolve, so this is resolved to the dependency's
+			// stable ID here rather than carrying the bare name forward.
+			dependsOn := make([]string, 0, len(command.DependsOn))
+			for _, dep := range command.DependsOn {
+				if depCommand, ok := commands[dep]; ok {
+					dependsOn = append(dependsOn, commandStableID(tabName, dep, depCommand))
+				}
+			}
+
 			rows = append(rows, RowData{
+				ID:          commandStableID(tabName, cmdName, command),
+				Tab:         tabName,
 				Title:       cmdName,
 				Description: description,
 				Command:     command,
+				DependsOn:   dependsOn,
 			})
 		}
 		tabsData = append(tabsData, TabData{
@@ -223,13 +1299,8 @@ func createTabContent(tabData TabData) fyne.CanvasObject {
 }
 
 func createRowWidget(row RowData) *fyne.Container {
-	processKey := fmt.Sprintf("%s-%s", row.Title, row.Description)
-
-	if _, exists := processStates[processKey]; !exists {
-		processStates[processKey] = &ProcessState{
-			Output: []string{"No logs available. Run the process to see logs."},
-		}
-	}
+	processKey := row.ID
+	getOrCreateProcessState(processKey)
 
 	titleLabel := widget.NewLabel(row.Title)
 	titleLabel.TextStyle = fyne.TextStyle{Bold: true}
@@ -237,6 +1308,12 @@ func createRowWidget(row RowData) *fyne.Container {
 	descLabel := widget.NewLabel(row.Description)
 	descLabel.TextStyle = fyne.TextStyle{Italic: true}
 
+	var envLabel *widget.Label
+	if names := injectedEnvNames(row.Command); len(names) > 0 {
+		envLabel = widget.NewLabel(fmt.Sprintf("env: %s", strings.Join(names, ", ")))
+		envLabel.TextStyle = fyne.TextStyle{Italic: true}
+	}
+
 	statusLabel := widget.NewLabel("Stopped")
 	statusLabel.Alignment = fyne.TextAlignTrailing
 
@@ -247,17 +1324,29 @@ func createRowWidget(row RowData) *fyne.Container {
 	logsButton.Importance = widget.MediumImportance
 
 	updateButtonState := func() {
-		state := processStates[processKey]
-		if state.Running {
+		state := processState(processKey)
+		state.Mutex.Lock()
+		running, stopping, hasRun, exitCode := state.Running, state.Stopping, state.HasRun, state.ExitCode
+		state.Mutex.Unlock()
+
+		if running {
 			actionButton.SetIcon(theme.MediaStopIcon())
 			actionButton.Text = ""
-			statusLabel.SetText("Running")
+			if stopping {
+				statusLabel.SetText("Stopping...")
+			} else {
+				statusLabel.SetText("Running")
+			}
 			logsButton.Enable()
 		} else {
 			actionButton.SetIcon(theme.MediaPlayIcon())
 			actionButton.Text = ""
-			statusLabel.SetText("Stopped")
-			if state.HasRun {
+			if hasRun && exitCode != 0 {
+				statusLabel.SetText(fmt.Sprintf("Stopped (exit %d)", exitCode))
+			} else {
+				statusLabel.SetText("Stopped")
+			}
+			if hasRun {
 				logsButton.Enable()
 			} else {
 				logsButton.Disable()
@@ -268,84 +1357,157 @@ func createRowWidget(row RowData) *fyne.Container {
 		statusLabel.Refresh()
 	}
 
-	actionButton.OnTapped = func() {
-		state := processStates[processKey]
+	node := &commandNode{
+		Name:      row.Title,
+		Row:       row,
+		State:     processState(processKey),
+		DependsOn: row.DependsOn,
+	}
+
+	var startProcess func()
+	startProcess = func() {
+		state := processState(processKey)
+
+		// Claim the right to start atomically: the Play button, the
+		// supervisor's autostart/cron ticks and a restart-backoff
+		// callback can all call startProcess concurrently, and must
+		// not both win a check-then-set race on state.Running.
+		state.Mutex.Lock()
 		if state.Running {
+			state.Mutex.Unlock()
+			return
+		}
+		state.Running = true
+		state.HasRun = true
+		state.StoppedByUser = false
+		state.Mutex.Unlock()
+
+		state.resetLogs(row.Command.MaxLogLines)
+		state.appendLog(streamSystem, fmt.Sprintf("Starting process: %s %v", row.Command.Exec, row.Command.Args))
+
+		cmd, _, err := buildExecCommand(row.Command)
+		if err != nil {
+			fmt.Printf("Error preparing command: %v\n", err)
+			state.appendLog(streamSystem, fmt.Sprintf("Error preparing command: %v", err))
 			state.Mutex.Lock()
-			if state.Cmd != nil && state.Cmd.Process != nil {
-				state.Cmd.Process.Kill()
-			}
 			state.Running = false
 			state.Mutex.Unlock()
 			fyne.Do(updateButtonState)
-		} else {
+			return
+		}
+
+		stdoutPipe, err := cmd.StdoutPipe()
+		if err != nil {
+			fmt.Printf("Error creating stdout pipe: %v\n", err)
+			state.appendLog(streamSystem, fmt.Sprintf("Error creating stdout pipe: %v", err))
+			state.Mutex.Lock()
+			state.Running = false
+			state.Mutex.Unlock()
+			fyne.Do(updateButtonState)
+			return
+		}
+
+		stderrPipe, err := cmd.StderrPipe()
+		if err != nil {
+			fmt.Printf("Error creating stderr pipe: %v\n", err)
+			state.appendLog(streamSystem, fmt.Sprintf("Error creating stderr pipe: %v", err))
 			state.Mutex.Lock()
-			state.Output = []string{fmt.Sprintf("Starting process: %s %v", row.Command.Exec, row.Command.Args)}
-			state.HasRun = true
+			state.Running = false
 			state.Mutex.Unlock()
+			fyne.Do(updateButtonState)
+			return
+		}
 
-			cmd := exec.Command(row.Command.Exec, row.Command.Args...)
+		state.Mutex.Lock()
+		state.Cmd = cmd
+		state.Mutex.Unlock()
+
+		fyne.Do(updateButtonState)
 
-			stdoutPipe, err := cmd.StdoutPipe()
+		go captureOutput(stdoutPipe, processKey, false, row.Title, row.Command.Notify)
+		go captureOutput(stderrPipe, processKey, true, row.Title, row.Command.Notify)
+
+		go func() {
+			err := cmd.Start()
 			if err != nil {
-				fmt.Printf("Error creating stdout pipe: %v\n", err)
+				state.appendLog(streamSystem, fmt.Sprintf("Failed to start process: %v", err))
 				state.Mutex.Lock()
-				state.Output = append(state.Output, fmt.Sprintf("Error creating stdout pipe: %v", err))
+				state.Running = false
 				state.Mutex.Unlock()
+				fyne.Do(updateButtonState)
 				return
 			}
 
-			stderrPipe, err := cmd.StderrPipe()
-			if err != nil {
-				fmt.Printf("Error creating stderr pipe: %v\n", err)
-				state.Mutex.Lock()
-				state.Output = append(state.Output, fmt.Sprintf("Error creating stderr pipe: %v", err))
-				state.Mutex.Unlock()
-				return
+			if row.Command.Notify.has(notifyOnStart) {
+				sendNotify(row.Command.Notify, notifyData{Name: row.Title})
 			}
 
+			err = cmd.Wait()
+			exitCode := 0
+			if err != nil {
+				exitCode = exitCodeFromError(err)
+			}
 			state.Mutex.Lock()
-			state.Cmd = cmd
-			state.Running = true
+			state.Running = false
+			state.Stopping = false
+			state.ExitCode = exitCode
+			state.LastExitOK = err == nil
+			stoppedByUser := state.StoppedByUser
 			state.Mutex.Unlock()
 
+			var lastEntry LogEntry
+			if err != nil {
+				lastEntry = state.appendLog(streamSystem, fmt.Sprintf("Process exited with error: %v", err))
+			} else {
+				lastEntry = state.appendLog(streamSystem, "Process completed successfully")
+			}
 			fyne.Do(updateButtonState)
 
-			go captureOutput(stdoutPipe, processKey, false)
-			go captureOutput(stderrPipe, processKey, true)
-
-			go func() {
-				err := cmd.Start()
-				if err != nil {
-					state.Mutex.Lock()
-					state.Output = append(state.Output, fmt.Sprintf("Failed to start process: %v", err))
-					state.Running = false
-					state.Mutex.Unlock()
-					fyne.Do(updateButtonState)
-					return
-				}
+			data := notifyData{Name: row.Title, ExitCode: exitCode, LastLine: lastEntry.Text}
+			if err != nil && row.Command.Notify.has(notifyOnFailure) {
+				sendNotify(row.Command.Notify, data)
+			} else if err == nil && row.Command.Notify.has(notifyOnSuccess) {
+				sendNotify(row.Command.Notify, data)
+			}
 
-				err = cmd.Wait()
-				state.Mutex.Lock()
-				state.Running = false
-				if err != nil {
-					state.Output = append(state.Output, fmt.Sprintf("Process exited with error: %v", err))
+			if !stoppedByUser {
+				shouldRestart := row.Command.Restart == restartAlways ||
+					(row.Command.Restart == restartOnFailure && err != nil)
+				if shouldRestart {
+					node.restartAttempts++
+					backoff := restartBackoff(node.restartAttempts)
+					state.appendLog(streamSystem, fmt.Sprintf("Restarting in %s (attempt %d)...", backoff, node.restartAttempts))
+					time.AfterFunc(backoff, startProcess)
 				} else {
-					state.Output = append(state.Output, "Process completed successfully")
+					node.restartAttempts = 0
 				}
-				state.Mutex.Unlock()
-				fyne.Do(updateButtonState)
-			}()
+			}
+		}()
+	}
+
+	node.Start = func() {
+		node.restartAttempts = 0
+		startProcess()
+	}
+	registerCommandNode(node)
+
+	actionButton.OnTapped = func() {
+		state := processState(processKey)
+		if state.Running {
+			state.Mutex.Lock()
+			state.StoppedByUser = true
+			state.Stopping = true
+			cmd := state.Cmd
+			state.Mutex.Unlock()
+			fyne.Do(updateButtonState)
+			go stopProcessGroup(state, cmd, row.Command.stopTimeout())
+		} else {
+			node.Start()
 		}
 	}
 
 	logsButton.OnTapped = func() {
-		state := processStates[processKey]
-		state.Mutex.Lock()
-		output := make([]string, len(state.Output))
-		copy(output, state.Output)
-		state.Mutex.Unlock()
-		showLogsDialog(row.Title, output)
+		showLogViewer(row.Title, processState(processKey))
 	}
 
 	fyne.Do(updateButtonState)
@@ -354,6 +1516,9 @@ func createRowWidget(row RowData) *fyne.Container {
 		titleLabel,
 		descLabel,
 	)
+	if envLabel != nil {
+		textContent.Add(envLabel)
+	}
 
 	mainRow := container.NewHBox(
 		textContent,
@@ -375,49 +1540,337 @@ func createRowWidget(row RowData) *fyne.Container {
 	return borderedRow
 }
 
-func captureOutput(reader io.ReadCloser, processKey string, isStderr bool) {
+func captureOutput(reader io.ReadCloser, processKey string, isStderr bool, cmdName string, notify *NotifyConfig) {
 	defer reader.Close()
 
 	scanner := bufio.NewScanner(reader)
 	for scanner.Scan() {
 		line := scanner.Text()
 
-		state, exists := processStates[processKey]
+		state, exists := getProcessState(processKey)
 		if !exists {
 			return
 		}
 
-		state.Mutex.Lock()
-		prefix := ""
+		stream := streamStdout
 		if isStderr {
-			prefix = "ERR: "
+			stream = streamStderr
+		}
+		state.appendLog(stream, line)
+
+		if isStderr && notify.has(notifyOnStderrMatch) {
+			re, err := notify.compiledStderrPattern()
+			if err != nil {
+				fmt.Printf("Error compiling stderr_pattern: %v\n", err)
+			} else if re != nil && re.MatchString(line) {
+				sendNotify(notify, notifyData{Name: cmdName, LastLine: line})
+			}
 		}
-		state.Output = append(state.Output, prefix+line)
-		state.Mutex.Unlock()
 	}
 
 	if err := scanner.Err(); err != nil {
 		fmt.Printf("Error reading output: %v\n", err)
-		state, exists := processStates[processKey]
+		state, exists := getProcessState(processKey)
 		if exists {
-			state.Mutex.Lock()
-			state.Output = append(state.Output, fmt.Sprintf("Error reading output: %v", err))
-			state.Mutex.Unlock()
+			state.appendLog(streamSystem, fmt.Sprintf("Error reading output: %v", err))
 		}
 	}
 }
 
-func showLogsDialog(title string, output []string) {
-	logText := strings.Join(output, "\n")
+// exitCodeFromError extracts the child process exit code from the error
+// returned by cmd.Wait, defaulting to -1 when it can't be determined.
+func exitCodeFromError(err error) int {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
+
+// logViewer holds the widgets and filter state backing a single "Logs"
+// dialog so its callbacks can recompute the visible rows without
+// threading everything through closures individually.
+type logViewer struct {
+	state       *ProcessState
+	all         []LogEntry
+	visible     []LogEntry
+	showStdout  bool
+	showStderr  bool
+	searchTerm  string
+	matches     []int
+	matchCursor int
+	follow      bool
+	unsubscribe func()
+	list        *widget.List
+}
+
+func newLogViewer(state *ProcessState) *logViewer {
+	return &logViewer{
+		state:      state,
+		showStdout: true,
+		showStderr: true,
+	}
+}
+
+func (v *logViewer) recompute() {
+	v.visible = v.visible[:0]
+	for _, entry := range v.all {
+		if entry.Stream == streamStdout && !v.showStdout {
+			continue
+		}
+		if entry.Stream == streamStderr && !v.showStderr {
+			continue
+		}
+		v.visible = append(v.visible, entry)
+	}
 
-	logWidget := widget.NewMultiLineEntry()
-	logWidget.SetText(logText)
-	logWidget.Wrapping = fyne.TextWrapWord
+	v.matches = v.matches[:0]
+	if v.searchTerm != "" {
+		term := strings.ToLower(v.searchTerm)
+		for i, entry := range v.visible {
+			if strings.Contains(strings.ToLower(entry.Text), term) {
+				v.matches = append(v.matches, i)
+			}
+		}
+	}
+	if v.matchCursor >= len(v.matches) {
+		v.matchCursor = 0
+	}
+}
 
-	scrollContainer := container.NewVScroll(logWidget)
-	scrollContainer.SetMinSize(fyne.NewSize(800, 500))
+func (v *logViewer) jumpToMatch(delta int) {
+	if len(v.matches) == 0 {
+		return
+	}
+	v.matchCursor = (v.matchCursor + delta + len(v.matches)) % len(v.matches)
+	row := v.matches[v.matchCursor]
+	v.list.Select(row)
+	v.list.ScrollTo(row)
+}
 
-	customDialog := dialog.NewCustom(title, "Close", scrollContainer, myWindow)
-	customDialog.Resize(fyne.NewSize(800, 500))
+// showLogViewer opens a searchable, filterable log viewer for state,
+// replacing the old single MultiLineEntry dump. It follows new output
+// live through state.subscribe instead of polling.
+func showLogViewer(title string, state *ProcessState) {
+	v := newLogViewer(state)
+	v.all = state.snapshotLogs()
+	v.recompute()
+
+	list := widget.NewList(
+		func() int { return len(v.visible) },
+		func() fyne.CanvasObject {
+			return container.NewHBox(widget.NewLabel(""))
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			if id < 0 || id >= len(v.visible) {
+				return
+			}
+			updateLogLineObject(obj.(*fyne.Container), v.visible[id])
+		},
+	)
+	v.list = list
+
+	searchEntry := widget.NewEntry()
+	searchEntry.SetPlaceHolder("Search logs...")
+
+	prevButton := widget.NewButtonWithIcon("", theme.NavigateBackIcon(), nil)
+	nextButton := widget.NewButtonWithIcon("", theme.NavigateNextIcon(), nil)
+
+	searchEntry.OnChanged = func(term string) {
+		v.searchTerm = term
+		v.recompute()
+		list.Refresh()
+		v.jumpToMatch(0)
+	}
+	prevButton.OnTapped = func() { v.jumpToMatch(-1) }
+	nextButton.OnTapped = func() { v.jumpToMatch(1) }
+
+	stdoutCheck := widget.NewCheck("stdout", func(checked bool) {
+		v.showStdout = checked
+		v.recompute()
+		list.Refresh()
+	})
+	stdoutCheck.SetChecked(true)
+
+	stderrCheck := widget.NewCheck("stderr", func(checked bool) {
+		v.showStderr = checked
+		v.recompute()
+		list.Refresh()
+	})
+	stderrCheck.SetChecked(true)
+
+	followCheck := widget.NewCheck("Follow tail", func(checked bool) {
+		v.follow = checked
+	})
+	followCheck.SetChecked(true)
+	v.follow = true
+
+	exportButton := widget.NewButtonWithIcon("Export logs", theme.DownloadIcon(), func() {
+		exportLogViewer(v)
+	})
+
+	toolbar := container.NewBorder(nil, nil, nil,
+		container.NewHBox(prevButton, nextButton),
+		searchEntry,
+	)
+
+	filterBar := container.NewHBox(stdoutCheck, stderrCheck, layout.NewSpacer(), followCheck, exportButton)
+
+	content := container.NewBorder(
+		container.NewVBox(toolbar, filterBar),
+		nil, nil, nil,
+		list,
+	)
+
+	ch, unsubscribe := state.subscribe()
+	v.unsubscribe = unsubscribe
+
+	go func() {
+		for entry := range ch {
+			entry := entry
+			fyne.Do(func() {
+				v.all = append(v.all, entry)
+				v.recompute()
+				list.Refresh()
+				if v.follow {
+					list.ScrollToBottom()
+				}
+			})
+		}
+	}()
+
+	customDialog := dialog.NewCustom(title, "Close", content, myWindow)
+	customDialog.Resize(fyne.NewSize(900, 560))
+	customDialog.SetOnClosed(func() {
+		v.unsubscribe()
+	})
 	customDialog.Show()
+
+	if v.follow {
+		list.ScrollToBottom()
+	}
+}
+
+func exportLogViewer(v *logViewer) {
+	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, myWindow)
+			return
+		}
+		if writer == nil {
+			return
+		}
+		defer writer.Close()
+
+		buf := bufio.NewWriter(writer)
+		for _, entry := range v.visible {
+			fmt.Fprintf(buf, "%s [%s] %s\n", entry.Time.Format("2006-01-02 15:04:05.000"), entry.Stream, entry.Text)
+		}
+		if err := buf.Flush(); err != nil {
+			dialog.ShowError(err, myWindow)
+		}
+	}, myWindow)
+	saveDialog.SetFileName("cmdeck-logs.txt")
+	saveDialog.Show()
+}
+
+// ansiSegment is a run of text sharing a single foreground color, the
+// result of parsing a line's ANSI SGR escape codes.
+type ansiSegment struct {
+	Text  string
+	Color color.Color
+}
+
+var ansiEscapeRe = regexp.MustCompile("\x1b\\[([0-9;]*)m")
+
+var ansiColors = map[int]color.Color{
+	30: color.Black,
+	31: color.RGBA{R: 205, G: 49, B: 49, A: 255},
+	32: color.RGBA{R: 13, G: 188, B: 121, A: 255},
+	33: color.RGBA{R: 229, G: 229, B: 16, A: 255},
+	34: color.RGBA{R: 36, G: 114, B: 200, A: 255},
+	35: color.RGBA{R: 188, G: 63, B: 188, A: 255},
+	36: color.RGBA{R: 17, G: 168, B: 205, A: 255},
+	37: color.White,
+	90: color.RGBA{R: 102, G: 102, B: 102, A: 255},
+	91: color.RGBA{R: 241, G: 76, B: 76, A: 255},
+	92: color.RGBA{R: 35, G: 209, B: 139, A: 255},
+	93: color.RGBA{R: 245, G: 245, B: 67, A: 255},
+	94: color.RGBA{R: 59, G: 142, B: 234, A: 255},
+	95: color.RGBA{R: 214, G: 112, B: 214, A: 255},
+	96: color.RGBA{R: 41, G: 184, B: 219, A: 255},
+	97: color.RGBA{R: 229, G: 229, B: 229, A: 255},
+}
+
+// parseANSILine splits a line on SGR color escapes into plain-text runs,
+// so the log viewer can render colored build/test output correctly.
+func parseANSILine(line string) []ansiSegment {
+	var segments []ansiSegment
+	var current color.Color
+
+	matches := ansiEscapeRe.FindAllStringSubmatchIndex(line, -1)
+	pos := 0
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		if start > pos {
+			segments = append(segments, ansiSegment{Text: line[pos:start], Color: current})
+		}
+		codeStr := line[m[2]:m[3]]
+		current = applyAnsiCodes(current, codeStr)
+		pos = end
+	}
+	if pos < len(line) {
+		segments = append(segments, ansiSegment{Text: line[pos:], Color: current})
+	}
+	if len(segments) == 0 {
+		segments = append(segments, ansiSegment{Text: line[pos:], Color: current})
+	}
+	return segments
+}
+
+func applyAnsiCodes(current color.Color, codeStr string) color.Color {
+	if codeStr == "" {
+		return nil
+	}
+	for _, part := range strings.Split(codeStr, ";") {
+		code, err := strconv.Atoi(part)
+		if err != nil {
+			continue
+		}
+		if code == 0 {
+			current = nil
+			continue
+		}
+		if c, ok := ansiColors[code]; ok {
+			current = c
+		}
+	}
+	return current
+}
+
+// updateLogLineObject rebuilds a log viewer row in place: a monospace
+// timestamp followed by the ANSI-colored text segments for the line.
+func updateLogLineObject(row *fyne.Container, entry LogEntry) {
+	row.Objects = row.Objects[:0]
+
+	ts := widget.NewLabel(entry.Time.Format("15:04:05.000"))
+	ts.TextStyle = fyne.TextStyle{Monospace: true}
+	row.Objects = append(row.Objects, ts)
+
+	if entry.Stream == streamStderr {
+		errTag := canvas.NewText("ERR", ansiColors[31])
+		errTag.TextStyle = fyne.TextStyle{Monospace: true, Bold: true}
+		row.Objects = append(row.Objects, errTag)
+	}
+
+	for _, seg := range parseANSILine(entry.Text) {
+		text := canvas.NewText(seg.Text, seg.Color)
+		if seg.Color == nil {
+			text = canvas.NewText(seg.Text, theme.ForegroundColor())
+		}
+		text.TextStyle = fyne.TextStyle{Monospace: true}
+		row.Objects = append(row.Objects, text)
+	}
+
+	row.Refresh()
 }
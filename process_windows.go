@@ -0,0 +1,36 @@
+//go:build windows
+
+package main
+
+import (
+	"os/exec"
+	"strconv"
+	"syscall"
+)
+
+// configureProcessGroup puts a not-yet-started command in its own process
+// group so stopProcessGroup can signal it and every child it spawns (a
+// shell wrapping a server, for instance) together.
+func configureProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+}
+
+// terminateProcessGroup asks the whole process tree to exit. Windows has
+// no SIGTERM equivalent for arbitrary processes, so this runs taskkill /T
+// without /F, which asks each process to close rather than forcing it;
+// processes that ignore the request are left for killProcessGroup's /F
+// once stop_timeout has elapsed.
+func terminateProcessGroup(cmd *exec.Cmd) error {
+	if cmd.Process == nil {
+		return nil
+	}
+	return exec.Command("taskkill", "/T", "/PID", strconv.Itoa(cmd.Process.Pid)).Run()
+}
+
+// killProcessGroup forcibly ends the whole process tree.
+func killProcessGroup(cmd *exec.Cmd) error {
+	if cmd.Process == nil {
+		return nil
+	}
+	return exec.Command("taskkill", "/F", "/T", "/PID", strconv.Itoa(cmd.Process.Pid)).Run()
+}